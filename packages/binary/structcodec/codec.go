@@ -0,0 +1,368 @@
+package structcodec
+
+import (
+	"reflect"
+	"unsafe"
+
+	"github.com/iotaledger/hive.go/marshalutil"
+	"golang.org/x/xerrors"
+)
+
+// exported returns fv with its read-only flag cleared via unsafe.Pointer, so that a field reached through
+// reflection can be read with Interface() and written with Set/SetBytes/SetUint/SetString even if the struct that
+// declares it (e.g. transfer.Transfer.hash) never exports it to this package. This is the same technique
+// encoding/gob and most third-party binary codecs use to reach unexported fields; it requires fv to be addressable,
+// which every field is when v is a pointer. Unmarshal always requires a pointer; Marshal also accepts a struct value,
+// in which case fv is returned unchanged and an unexported field cannot be read.
+func exported(fv reflect.Value) reflect.Value {
+	if !fv.CanAddr() {
+		return fv
+	}
+
+	return reflect.NewAt(fv.Type(), unsafe.Pointer(fv.UnsafeAddr())).Elem()
+}
+
+// region Marshal //////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Marshal encodes v (a struct, or a pointer to one) into bytes according to the Plan derived from its `bin:"..."`
+// struct tags. Pass a pointer if the struct has unexported bin-tagged fields, since reaching those relies on the
+// field being addressable.
+func Marshal(v interface{}) (bytes []byte, err error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+
+	plan, err := PlanOf(rv.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	marshalUtil := marshalutil.New()
+	if err = plan.encode(marshalUtil, rv); err != nil {
+		return nil, err
+	}
+
+	return marshalUtil.Bytes(), nil
+}
+
+// encode writes every field of rv (a struct value matching plan.typ) to marshalUtil.
+func (plan *Plan) encode(marshalUtil *marshalutil.MarshalUtil, rv reflect.Value) (err error) {
+	for _, field := range plan.fields {
+		if err = encodeField(marshalUtil, exported(rv.Field(field.index)), field.tag); err != nil {
+			return xerrors.Errorf("field %s of %s: %w", field.name, plan.typ, err)
+		}
+	}
+
+	return
+}
+
+// encodeField writes a single field according to its FieldTag.
+func encodeField(marshalUtil *marshalutil.MarshalUtil, fv reflect.Value, tag FieldTag) (err error) {
+	if tag.Optional {
+		if fv.IsNil() {
+			marshalUtil.WriteBool(false)
+			return
+		}
+		marshalUtil.WriteBool(true)
+		fv = fv.Elem()
+	}
+
+	switch {
+	case tag.Tail:
+		marshalUtil.WriteBytes(fv.Bytes())
+		return
+	case tag.Len != "":
+		return encodeLenPrefixed(marshalUtil, fv, tag.Len)
+	case tag.Int != "":
+		return encodeInt(marshalUtil, fv, tag.Int)
+	default:
+		return encodeComposite(marshalUtil, fv, tag)
+	}
+}
+
+// encodeLenPrefixed writes a field preceded by its length, encoded as lenKind. A []byte/string writes its byte length
+// and its raw bytes; any other slice type writes its element count followed by the Bytes() of every entry, which
+// must implement Marshaler.
+func encodeLenPrefixed(marshalUtil *marshalutil.MarshalUtil, fv reflect.Value, lenKind IntKind) (err error) {
+	if fv.Kind() == reflect.String {
+		data := []byte(fv.String())
+		if err = writeInt(marshalUtil, lenKind, uint64(len(data))); err != nil {
+			return
+		}
+		marshalUtil.WriteBytes(data)
+
+		return
+	}
+	if fv.Kind() != reflect.Slice {
+		return xerrors.Errorf("%s: %w", fv.Kind(), ErrUnsupportedType)
+	}
+	if fv.Type().Elem().Kind() == reflect.Uint8 {
+		data := fv.Bytes()
+		if err = writeInt(marshalUtil, lenKind, uint64(len(data))); err != nil {
+			return
+		}
+		marshalUtil.WriteBytes(data)
+
+		return
+	}
+
+	if err = writeInt(marshalUtil, lenKind, uint64(fv.Len())); err != nil {
+		return
+	}
+	for i := 0; i < fv.Len(); i++ {
+		marshaler, ok := fv.Index(i).Interface().(Marshaler)
+		if !ok {
+			return xerrors.Errorf("%s does not implement structcodec.Marshaler: %w", fv.Type().Elem(), ErrUnsupportedType)
+		}
+		marshalUtil.WriteBytes(marshaler.Bytes())
+	}
+
+	return
+}
+
+// encodeInt writes a fixed size unsigned integer field.
+func encodeInt(marshalUtil *marshalutil.MarshalUtil, fv reflect.Value, kind IntKind) error {
+	return writeInt(marshalUtil, kind, fv.Uint())
+}
+
+// writeInt writes value using the width selected by kind.
+func writeInt(marshalUtil *marshalutil.MarshalUtil, kind IntKind, value uint64) error {
+	switch kind {
+	case Uint8:
+		marshalUtil.WriteByte(byte(value))
+	case Uint16:
+		marshalUtil.WriteUint16(uint16(value))
+	case Uint32:
+		marshalUtil.WriteUint32(uint32(value))
+	case Uint64:
+		marshalUtil.WriteUint64(value)
+	default:
+		return xerrors.Errorf("%q: %w", kind, ErrUnsupportedType)
+	}
+
+	return nil
+}
+
+// encodeComposite writes a field that brings its own Bytes() implementation (e.g. ledgerstate.Inputs), honoring
+// NilIsEmpty for nil-able kinds the same way ledgerstate.TransactionEssence.Bytes() writes a 0 length Payload
+// instead of distinguishing "no Payload" from "empty Payload".
+func encodeComposite(marshalUtil *marshalutil.MarshalUtil, fv reflect.Value, tag FieldTag) error {
+	if tag.NilIsEmpty && isNilable(fv.Kind()) && fv.IsNil() {
+		marshalUtil.WriteUint32(0)
+		return nil
+	}
+
+	marshaler, ok := fv.Interface().(Marshaler)
+	if !ok {
+		return xerrors.Errorf("%s does not implement structcodec.Marshaler: %w", fv.Type(), ErrUnsupportedType)
+	}
+	marshalUtil.WriteBytes(marshaler.Bytes())
+
+	return nil
+}
+
+// isNilable reports whether values of kind can meaningfully be compared against nil.
+func isNilable(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+		return true
+	default:
+		return false
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Unmarshal ////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Unmarshal decodes data into v (a non-nil pointer to a struct) according to the Plan derived from its `bin:"..."`
+// struct tags, and returns the number of bytes it consumed.
+func Unmarshal(data []byte, v interface{}) (consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(data)
+	if err = UnmarshalFromMarshalUtil(marshalUtil, v); err != nil {
+		return
+	}
+
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// UnmarshalFromMarshalUtil decodes v (a non-nil pointer to a struct) from marshalUtil, for composing with other
+// FromMarshalUtil based parsers.
+func UnmarshalFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil, v interface{}) (err error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return xerrors.Errorf("v must be a non-nil pointer to a struct: %w", ErrUnsupportedType)
+	}
+	rv = rv.Elem()
+
+	plan, err := PlanOf(rv.Type())
+	if err != nil {
+		return err
+	}
+
+	return plan.decode(marshalUtil, rv)
+}
+
+// decode fills every field of rv (a struct value matching plan.typ) from marshalUtil.
+func (plan *Plan) decode(marshalUtil *marshalutil.MarshalUtil, rv reflect.Value) (err error) {
+	for _, field := range plan.fields {
+		if err = decodeField(marshalUtil, exported(rv.Field(field.index)), field.tag); err != nil {
+			return xerrors.Errorf("field %s of %s: %w", field.name, plan.typ, err)
+		}
+	}
+
+	return
+}
+
+// decodeField fills a single field according to its FieldTag.
+func decodeField(marshalUtil *marshalutil.MarshalUtil, fv reflect.Value, tag FieldTag) (err error) {
+	target := fv
+	if tag.Optional {
+		present, presentErr := marshalUtil.ReadBool()
+		if presentErr != nil {
+			return xerrors.Errorf("failed to parse presence flag: %w", presentErr)
+		}
+		if !present {
+			fv.Set(reflect.Zero(fv.Type()))
+			return nil
+		}
+
+		target = reflect.New(fv.Type().Elem())
+		fv.Set(target)
+		target = target.Elem()
+	}
+
+	switch {
+	case tag.Tail:
+		remaining, remainingErr := marshalUtil.ReadRemainingBytes()
+		if remainingErr != nil {
+			return xerrors.Errorf("failed to parse tail bytes: %w", remainingErr)
+		}
+		target.SetBytes(remaining)
+
+		return nil
+	case tag.Len != "":
+		return decodeLenPrefixed(marshalUtil, target, tag.Len)
+	case tag.Int != "":
+		return decodeInt(marshalUtil, target, tag.Int)
+	default:
+		return decodeComposite(marshalUtil, target)
+	}
+}
+
+// decodeLenPrefixed reads a field preceded by its length, encoded as lenKind, mirroring encodeLenPrefixed: a
+// []byte/string reads its raw bytes, any other slice type reads as many elements as the prefix counts, each through
+// the decoder registered for the element type.
+func decodeLenPrefixed(marshalUtil *marshalutil.MarshalUtil, fv reflect.Value, lenKind IntKind) error {
+	length, err := readInt(marshalUtil, lenKind)
+	if err != nil {
+		return xerrors.Errorf("failed to parse length prefix: %w", err)
+	}
+
+	if fv.Kind() == reflect.String || (fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() == reflect.Uint8) {
+		data, dataErr := marshalUtil.ReadBytes(int(length))
+		if dataErr != nil {
+			return xerrors.Errorf("failed to parse %d bytes: %w", length, dataErr)
+		}
+
+		if fv.Kind() == reflect.String {
+			fv.SetString(string(data))
+		} else {
+			fv.SetBytes(data)
+		}
+
+		return nil
+	}
+	if fv.Kind() != reflect.Slice {
+		return xerrors.Errorf("%s: %w", fv.Kind(), ErrUnsupportedType)
+	}
+
+	elemType := fv.Type().Elem()
+	slice := reflect.MakeSlice(fv.Type(), 0, int(length))
+	for i := uint64(0); i < length; i++ {
+		value, valueErr := decodeRegistered(marshalUtil, elemType)
+		if valueErr != nil {
+			return xerrors.Errorf("failed to parse element %d: %w", i, valueErr)
+		}
+		slice = reflect.Append(slice, value)
+	}
+	fv.Set(slice)
+
+	return nil
+}
+
+// decodeInt reads a fixed size unsigned integer field.
+func decodeInt(marshalUtil *marshalutil.MarshalUtil, fv reflect.Value, kind IntKind) error {
+	value, err := readInt(marshalUtil, kind)
+	if err != nil {
+		return err
+	}
+	fv.SetUint(value)
+
+	return nil
+}
+
+// readInt reads an unsigned integer whose width is selected by kind.
+func readInt(marshalUtil *marshalutil.MarshalUtil, kind IntKind) (value uint64, err error) {
+	switch kind {
+	case Uint8:
+		var v byte
+		v, err = marshalUtil.ReadByte()
+		value = uint64(v)
+	case Uint16:
+		var v uint16
+		v, err = marshalUtil.ReadUint16()
+		value = uint64(v)
+	case Uint32:
+		var v uint32
+		v, err = marshalUtil.ReadUint32()
+		value = uint64(v)
+	case Uint64:
+		value, err = marshalUtil.ReadUint64()
+	default:
+		err = xerrors.Errorf("%q: %w", kind, ErrUnsupportedType)
+	}
+
+	return
+}
+
+// decodeComposite reads a field whose type was registered via RegisterMarshaler.
+func decodeComposite(marshalUtil *marshalutil.MarshalUtil, fv reflect.Value) error {
+	value, err := decodeRegistered(marshalUtil, fv.Type())
+	if err != nil {
+		return err
+	}
+	fv.Set(value)
+
+	return nil
+}
+
+// decodeRegistered parses a value of type t using the UnmarshalFunc that was registered for it via
+// RegisterMarshaler, advancing marshalUtil past exactly the bytes that the decoder consumed.
+func decodeRegistered(marshalUtil *marshalutil.MarshalUtil, t reflect.Type) (reflect.Value, error) {
+	unmarshal, exists := unmarshalerFor(t)
+	if !exists {
+		return reflect.Value{}, xerrors.Errorf("%s has no structcodec.RegisterMarshaler decoder: %w", t, ErrUnsupportedType)
+	}
+
+	remaining, err := marshalUtil.ReadRemainingBytes()
+	if err != nil {
+		return reflect.Value{}, xerrors.Errorf("failed to read remaining bytes for %s: %w", t, err)
+	}
+
+	value, consumedBytes, err := unmarshal(remaining)
+	if err != nil {
+		return reflect.Value{}, xerrors.Errorf("failed to parse %s: %w", t, err)
+	}
+
+	if err = marshalUtil.ReadSeek(marshalUtil.ReadOffset() - len(remaining) + consumedBytes); err != nil {
+		return reflect.Value{}, xerrors.Errorf("failed to seek past parsed %s: %w", t, err)
+	}
+
+	return reflect.ValueOf(value), nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,107 @@
+package structcodec_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/binary/structcodec"
+)
+
+// demoRecord exercises every bin tag kind that structcodec supports.
+type demoRecord struct {
+	Version byte   `bin:"uint8"`
+	Amount  uint64 `bin:"uint64"`
+	Name    string `bin:"len=uint16"`
+	Tail    []byte `bin:"tail"`
+}
+
+func TestRoundTrip(t *testing.T) {
+	original := &demoRecord{
+		Version: 3,
+		Amount:  1337,
+		Name:    "iota",
+		Tail:    []byte{0xde, 0xad, 0xbe, 0xef},
+	}
+
+	data, err := structcodec.Marshal(original)
+	require.NoError(t, err)
+
+	var decoded demoRecord
+	consumedBytes, err := structcodec.Unmarshal(data, &decoded)
+	require.NoError(t, err)
+	assert.Equal(t, len(data), consumedBytes)
+	assert.Equal(t, *original, decoded)
+}
+
+// demoElement is a composite field type whose Bytes()/FromBytes pair is reached through RegisterMarshaler, the
+// same way transfer.OutputReference is.
+type demoElement struct {
+	value byte
+}
+
+func (d *demoElement) Bytes() []byte {
+	return []byte{d.value}
+}
+
+func demoElementFromBytes(data []byte) (value interface{}, consumedBytes int, err error) {
+	if len(data) < 1 {
+		return nil, 0, fmt.Errorf("not enough bytes to parse demoElement")
+	}
+
+	return &demoElement{value: data[0]}, 1, nil
+}
+
+func init() {
+	structcodec.RegisterMarshaler(&demoElement{}, demoElementFromBytes)
+}
+
+// unexportedFieldRecord mirrors the shape of transfer.Transfer: every bin-tagged field is unexported relative to
+// the structcodec package doing the reflecting, which used to panic before Marshal/Unmarshal started resolving
+// field addresses via unsafe.Pointer/reflect.NewAt.
+type unexportedFieldRecord struct {
+	version byte           `bin:"uint8"`
+	items   []*demoElement `bin:"len=uint8"`
+	tail    []byte         `bin:"tail"`
+}
+
+func TestRoundTripUnexportedFields(t *testing.T) {
+	original := &unexportedFieldRecord{
+		version: 5,
+		items:   []*demoElement{{value: 1}, {value: 2}},
+		tail:    []byte{0xaa, 0xbb},
+	}
+
+	data, err := structcodec.Marshal(original)
+	require.NoError(t, err)
+
+	var decoded unexportedFieldRecord
+	consumedBytes, err := structcodec.Unmarshal(data, &decoded)
+	require.NoError(t, err)
+	assert.Equal(t, len(data), consumedBytes)
+	assert.Equal(t, *original, decoded)
+}
+
+func FuzzRoundTrip(f *testing.F) {
+	f.Add(byte(0), uint64(0), "", []byte(nil))
+	f.Add(byte(127), uint64(42), "goshimmer", []byte{1, 2, 3})
+
+	f.Fuzz(func(t *testing.T, version byte, amount uint64, name string, tail []byte) {
+		original := &demoRecord{Version: version, Amount: amount, Name: name, Tail: tail}
+
+		data, err := structcodec.Marshal(original)
+		require.NoError(t, err)
+
+		var decoded demoRecord
+		consumedBytes, err := structcodec.Unmarshal(data, &decoded)
+		require.NoError(t, err)
+		assert.Equal(t, len(data), consumedBytes)
+		assert.Equal(t, original.Version, decoded.Version)
+		assert.Equal(t, original.Amount, decoded.Amount)
+		assert.Equal(t, original.Name, decoded.Name)
+		assert.True(t, bytes.Equal(original.Tail, decoded.Tail))
+	})
+}
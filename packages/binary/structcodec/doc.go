@@ -0,0 +1,22 @@
+// Package structcodec derives a marshalutil based Marshal/Unmarshal pair for a struct from its field tags, so that a
+// type with a handful of fixed-width/length-prefixed/tail fields (e.g. transfer.Transfer's hash and inputs) does not
+// need its own hand-written Bytes()/FromMarshalUtil pair just to repeat the same read/write calls every other such
+// type already makes.
+//
+// A field is described by a `bin:"..."` struct tag:
+//
+//	bin:"uint8"      // fixed width unsigned integer (uint8, uint16, uint32, uint64)
+//	bin:"len=uint16"  // []byte/string prefixed by its length, or a slice of Marshaler elements prefixed by its
+//	                  // element count, encoded as the given unsigned integer type
+//	bin:"optional"    // a pointer field that is preceded by a presence byte
+//	bin:"tail"        // a []byte field that consumes the remaining bytes without any length prefix
+//	bin:"nil=empty"   // a nil-able field (pointer, slice, interface) is indistinguishable from its zero value
+//
+// Untagged fields fall back to calling Bytes() (for encoding) and a matching FromBytes-shaped function (for
+// decoding) that was registered for their type via RegisterMarshaler, which is how composite fields such as
+// ledgerstate.Inputs or ledgerstate.Outputs are supported without structcodec needing to know their layout.
+//
+// PlanOf computes and caches a type's encode/decode Plan via reflection, which is what Marshal/Unmarshal use directly.
+// Marshal/Unmarshal reach struct fields (including unexported ones, such as transfer.Transfer.hash/inputs) by
+// resolving their address through unsafe.Pointer, so a struct does not need to export a field just to be tagged.
+package structcodec
@@ -0,0 +1,142 @@
+package structcodec
+
+import (
+	"reflect"
+	"sync"
+
+	"golang.org/x/xerrors"
+)
+
+// region errors ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ErrUnsupportedType is returned when Plan encounters a type or field that structcodec does not know how to encode.
+var ErrUnsupportedType = xerrors.New("unsupported type for structcodec")
+
+// ErrTailMustBeLast is returned when a `bin:"tail"` field is not the last field of its struct.
+var ErrTailMustBeLast = xerrors.New("a bin:\"tail\" field must be the last field of the struct")
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Marshaler registry ///////////////////////////////////////////////////////////////////////////////////////////
+
+// Marshaler is implemented by composite field types (e.g. ledgerstate.Inputs) that bring their own Bytes() encoding
+// and are therefore exempt from needing a `bin:"..."` tag.
+type Marshaler interface {
+	Bytes() []byte
+}
+
+// UnmarshalFunc parses a value of a registered type from raw bytes, returning how many bytes it consumed. It mirrors
+// the FromBytes(bytes []byte) (value, consumedBytes int, err error) convention already used throughout ledgerstate.
+type UnmarshalFunc func(data []byte) (value interface{}, consumedBytes int, err error)
+
+var (
+	// unmarshalers holds the decode side of every type that was registered via RegisterMarshaler.
+	unmarshalers = make(map[reflect.Type]UnmarshalFunc)
+
+	// unmarshalersMutex protects unmarshalers against concurrent access.
+	unmarshalersMutex sync.RWMutex
+)
+
+// RegisterMarshaler registers decode support for fields of the type of sample (a zero value of the target type, e.g.
+// ledgerstate.Inputs{}) that are not covered by a `bin:"..."` tag. sample's type must already implement Marshaler.
+func RegisterMarshaler(sample interface{}, unmarshal UnmarshalFunc) {
+	unmarshalersMutex.Lock()
+	defer unmarshalersMutex.Unlock()
+
+	unmarshalers[reflect.TypeOf(sample)] = unmarshal
+}
+
+// unmarshalerFor returns the UnmarshalFunc registered for t, if any.
+func unmarshalerFor(t reflect.Type) (unmarshal UnmarshalFunc, exists bool) {
+	unmarshalersMutex.RLock()
+	defer unmarshalersMutex.RUnlock()
+
+	unmarshal, exists = unmarshalers[t]
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Plan /////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// fieldPlan is the resolved encode/decode strategy for a single struct field.
+type fieldPlan struct {
+	index int
+	name  string
+	tag   FieldTag
+}
+
+// Plan is the reflection-derived, per-type encode/decode strategy that Marshal and Unmarshal execute. It is built
+// once per struct type and cached by PlanOf so that repeated (de-)serialization only pays the reflection cost once.
+type Plan struct {
+	typ    reflect.Type
+	fields []fieldPlan
+}
+
+var (
+	// plans caches the Plan of every struct type that has been encoded/decoded so far.
+	plans = make(map[reflect.Type]*Plan)
+
+	// plansMutex protects plans against concurrent access.
+	plansMutex sync.RWMutex
+)
+
+// PlanOf returns the cached Plan for t (a struct type, or a pointer to one), building and caching it on first use.
+func PlanOf(t reflect.Type) (plan *Plan, err error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		err = xerrors.Errorf("%s is not a struct: %w", t, ErrUnsupportedType)
+		return
+	}
+
+	plansMutex.RLock()
+	plan, exists := plans[t]
+	plansMutex.RUnlock()
+	if exists {
+		return
+	}
+
+	if plan, err = buildPlan(t); err != nil {
+		return nil, err
+	}
+
+	plansMutex.Lock()
+	plans[t] = plan
+	plansMutex.Unlock()
+
+	return
+}
+
+// buildPlan parses the bin tag of every field of t and makes sure that at most one trailing field uses "tail".
+func buildPlan(t reflect.Type) (plan *Plan, err error) {
+	plan = &Plan{typ: t}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag, tagErr := ParseTag(field)
+		if tagErr != nil {
+			err = tagErr
+			return
+		}
+		if tag.Ignore {
+			continue
+		}
+
+		plan.fields = append(plan.fields, fieldPlan{index: i, name: field.Name, tag: tag})
+	}
+
+	for i, field := range plan.fields {
+		if field.tag.Tail && i != len(plan.fields)-1 {
+			err = xerrors.Errorf("field %s of %s: %w", field.name, t, ErrTailMustBeLast)
+			return
+		}
+	}
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
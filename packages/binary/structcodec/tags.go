@@ -0,0 +1,99 @@
+package structcodec
+
+import (
+	"reflect"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// region FieldTag /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// IntKind identifies the width of a fixed size unsigned integer, either as a field's own encoding or as the encoding
+// of a "len=" length prefix.
+type IntKind string
+
+// IntKind values supported by the "uint..." and "len=uint..." tag options.
+const (
+	Uint8  IntKind = "uint8"
+	Uint16 IntKind = "uint16"
+	Uint32 IntKind = "uint32"
+	Uint64 IntKind = "uint64"
+)
+
+// FieldTag is the parsed form of a `bin:"..."` struct tag.
+type FieldTag struct {
+	// Ignore is true for a field tagged `bin:"-"`, which structcodec skips entirely.
+	Ignore bool
+
+	// Int is set if the field (or, for Len, the length prefix) is encoded as a fixed size unsigned integer.
+	Int IntKind
+
+	// Len is set if the field is a []byte/string that is encoded with a length prefix of the given IntKind, or a
+	// slice of Marshaler elements that is encoded with an element count prefix of the given IntKind.
+	Len IntKind
+
+	// Tail is true for a []byte field that consumes the rest of the input without a length prefix. At most one
+	// field per struct may set Tail, and it must be the last field.
+	Tail bool
+
+	// Optional is true for a pointer field that is preceded by a single presence byte.
+	Optional bool
+
+	// NilIsEmpty is true for a nil-able field (pointer, slice, interface) whose nil value is encoded identically to
+	// its zero value, mirroring how ledgerstate.TransactionEssence.Bytes() writes a 0 length Payload instead of
+	// distinguishing "no Payload" from "empty Payload".
+	NilIsEmpty bool
+}
+
+// ParseTag parses the `bin:"..."` struct tag of field. A field without a bin tag returns the zero FieldTag, which
+// tells Plan to fall back to the field's own Bytes()/registered decoder.
+func ParseTag(field reflect.StructField) (fieldTag FieldTag, err error) {
+	raw, ok := field.Tag.Lookup("bin")
+	if !ok {
+		return
+	}
+
+	for _, option := range strings.Split(raw, ",") {
+		option = strings.TrimSpace(option)
+
+		switch {
+		case option == "-":
+			fieldTag.Ignore = true
+		case option == "tail":
+			fieldTag.Tail = true
+		case option == "optional":
+			fieldTag.Optional = true
+		case option == "nil=empty":
+			fieldTag.NilIsEmpty = true
+		case strings.HasPrefix(option, "len="):
+			fieldTag.Len = IntKind(strings.TrimPrefix(option, "len="))
+			if !fieldTag.Len.valid() {
+				err = xerrors.Errorf("field %s: invalid len= integer kind %q: %w", field.Name, fieldTag.Len, ErrInvalidTag)
+				return
+			}
+		case IntKind(option).valid():
+			fieldTag.Int = IntKind(option)
+		default:
+			err = xerrors.Errorf("field %s: unknown bin tag option %q: %w", field.Name, option, ErrInvalidTag)
+			return
+		}
+	}
+
+	return
+}
+
+// valid reports whether k is one of the supported fixed size unsigned integer kinds.
+func (k IntKind) valid() bool {
+	switch k {
+	case Uint8, Uint16, Uint32, Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ErrInvalidTag is returned when a `bin:"..."` struct tag could not be parsed.
+var ErrInvalidTag = xerrors.New("invalid structcodec struct tag")
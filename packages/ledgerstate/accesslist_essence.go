@@ -0,0 +1,242 @@
+package ledgerstate
+
+import (
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/stringify"
+	"github.com/iotaledger/hive.go/typeutils"
+	"golang.org/x/xerrors"
+)
+
+// region AccessListEssenceVersion /////////////////////////////////////////////////////////////////////////////////////
+
+// AccessListEssenceVersion is the TransactionEssenceVersion that AccessListEssence is registered under.
+const AccessListEssenceVersion TransactionEssenceVersion = MinTypedTransactionEssenceVersion
+
+// init registers AccessListEssence with the typed-envelope registry introduced for TransactionEssence.
+func init() {
+	RegisterTransactionEssenceType(AccessListEssenceVersion, AccessListEssenceFromMarshalUtil)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region AccessList ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// AccessList is the declared set of OutputIDs that a transaction claims it will consume, carried by an
+// AccessListEssence. This is the wire type only: declaring which Outputs/Addresses a transaction touches is a
+// prerequisite for a booker to later shard and parallelize validation by non-conflicting AccessList, but that
+// booker integration (and the benchmarks showing its throughput effect) is not part of this change and remains
+// future work.
+type AccessList []OutputID
+
+// Add appends outputID to the AccessList if it is not already contained in it.
+func (a *AccessList) Add(outputID OutputID) {
+	if a.Contains(outputID) {
+		return
+	}
+
+	*a = append(*a, outputID)
+}
+
+// Contains returns true if outputID is part of the AccessList.
+func (a AccessList) Contains(outputID OutputID) bool {
+	for _, containedOutputID := range a {
+		if containedOutputID == outputID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Bytes returns a marshaled version of the AccessList.
+func (a AccessList) Bytes() []byte {
+	marshalUtil := marshalutil.New().WriteUint32(uint32(len(a)))
+	for _, outputID := range a {
+		marshalUtil.Write(outputID)
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// AccessListFromMarshalUtil unmarshals an AccessList using a MarshalUtil (for easier unmarshaling).
+func AccessListFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (accessList AccessList, err error) {
+	outputIDCount, err := marshalUtil.ReadUint32()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse AccessList length from MarshalUtil: %w", err)
+		return
+	}
+
+	accessList = make(AccessList, 0, outputIDCount)
+	for i := uint32(0); i < outputIDCount; i++ {
+		outputID, outputIDErr := OutputIDFromMarshalUtil(marshalUtil)
+		if outputIDErr != nil {
+			err = xerrors.Errorf("failed to parse OutputID from MarshalUtil: %w", outputIDErr)
+			return
+		}
+
+		accessList = append(accessList, outputID)
+	}
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region AccessListEssence ////////////////////////////////////////////////////////////////////////////////////////////
+
+// ErrAccessListViolation is returned by ValidateAccessList when a transaction touched an Output or Address that was
+// not part of its declared AccessList/Addresses, which a caller can use to reject the transaction before trusting
+// whatever grouping it declared.
+var ErrAccessListViolation = xerrors.New("transaction accessed an Output or Address outside of its declared AccessList")
+
+// AccessListEssence is an EssenceBody that augments the legacy Inputs/Outputs/Payload layout with a declared
+// read/write set: the AccessList of OutputIDs it consumes plus the Addresses it touches via its Payload. It is
+// registered under AccessListEssenceVersion.
+type AccessListEssence struct {
+	inputs     Inputs
+	outputs    Outputs
+	payload    payload.Payload
+	accessList AccessList
+	addresses  []Address
+}
+
+// NewAccessListEssence creates a new AccessListEssence from the given details.
+func NewAccessListEssence(inputs Inputs, outputs Outputs, txPayload payload.Payload, accessList AccessList, addresses []Address) *AccessListEssence {
+	return &AccessListEssence{
+		inputs:     inputs,
+		outputs:    outputs,
+		payload:    txPayload,
+		accessList: accessList,
+		addresses:  addresses,
+	}
+}
+
+// AccessListEssenceFromMarshalUtil unmarshals an AccessListEssence using a MarshalUtil (for easier unmarshaling). Its
+// signature matches EssenceBodyFromMarshalUtilFunc so it can be passed directly to RegisterTransactionEssenceType.
+func AccessListEssenceFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (body EssenceBody, err error) {
+	essence := &AccessListEssence{}
+	if essence.inputs, err = InputsFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse Inputs from MarshalUtil: %w", err)
+		return
+	}
+	if essence.outputs, err = OutputsFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse Outputs from MarshalUtil: %w", err)
+		return
+	}
+	if essence.payload, err = payload.FromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse Payload from MarshalUtil: %w", err)
+		return
+	}
+	if essence.accessList, err = AccessListFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse AccessList from MarshalUtil: %w", err)
+		return
+	}
+
+	addressCount, err := marshalUtil.ReadUint32()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse address count from MarshalUtil: %w", err)
+		return
+	}
+	essence.addresses = make([]Address, 0, addressCount)
+	for i := uint32(0); i < addressCount; i++ {
+		address, addressErr := AddressFromMarshalUtil(marshalUtil)
+		if addressErr != nil {
+			err = xerrors.Errorf("failed to parse Address from MarshalUtil: %w", addressErr)
+			return
+		}
+
+		essence.addresses = append(essence.addresses, address)
+	}
+	body = essence
+
+	return
+}
+
+// Inputs returns the Inputs of the AccessListEssence.
+func (a *AccessListEssence) Inputs() Inputs {
+	return a.inputs
+}
+
+// Outputs returns the Outputs of the AccessListEssence.
+func (a *AccessListEssence) Outputs() Outputs {
+	return a.outputs
+}
+
+// Payload returns the Payload of the AccessListEssence.
+func (a *AccessListEssence) Payload() payload.Payload {
+	return a.payload
+}
+
+// AccessList returns the declared set of OutputIDs that the AccessListEssence claims to consume.
+func (a *AccessListEssence) AccessList() AccessList {
+	return a.accessList
+}
+
+// Addresses returns the declared set of Addresses that the AccessListEssence claims to touch via its Payload.
+func (a *AccessListEssence) Addresses() []Address {
+	return a.addresses
+}
+
+// Bytes returns a marshaled version of the AccessListEssence.
+func (a *AccessListEssence) Bytes() []byte {
+	marshalUtil := marshalutil.New().
+		Write(a.inputs).
+		Write(a.outputs)
+
+	if !typeutils.IsInterfaceNil(a.payload) {
+		marshalUtil.Write(a.payload)
+	} else {
+		marshalUtil.WriteUint32(0)
+	}
+
+	marshalUtil.WriteBytes(a.accessList.Bytes())
+
+	marshalUtil.WriteUint32(uint32(len(a.addresses)))
+	for _, addr := range a.addresses {
+		marshalUtil.Write(addr)
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// String returns a human readable version of the AccessListEssence.
+func (a *AccessListEssence) String() string {
+	return stringify.Struct("AccessListEssence",
+		stringify.StructField("inputs", a.inputs),
+		stringify.StructField("outputs", a.outputs),
+		stringify.StructField("payload", a.payload),
+		stringify.StructField("accessList", a.accessList),
+		stringify.StructField("addresses", a.addresses),
+	)
+}
+
+// ValidateAccessList checks that touchedOutputIDs and touchedAddresses - the Outputs and Addresses a caller actually
+// accessed while processing the transaction - are fully covered by the declared AccessList and Addresses. It returns
+// ErrAccessListViolation if an undeclared Output or Address was touched.
+func (a *AccessListEssence) ValidateAccessList(touchedOutputIDs []OutputID, touchedAddresses []Address) (err error) {
+	for _, outputID := range touchedOutputIDs {
+		if !a.accessList.Contains(outputID) {
+			err = xerrors.Errorf("output %s was not part of the declared AccessList: %w", outputID, ErrAccessListViolation)
+			return
+		}
+	}
+
+	declaredAddresses := make(map[Address]struct{}, len(a.addresses))
+	for _, addr := range a.addresses {
+		declaredAddresses[addr] = struct{}{}
+	}
+	for _, addr := range touchedAddresses {
+		if _, declared := declaredAddresses[addr]; !declared {
+			err = xerrors.Errorf("address %s was not part of the declared AccessList: %w", addr, ErrAccessListViolation)
+			return
+		}
+	}
+
+	return
+}
+
+// code contract (make sure the struct implements all required methods)
+var _ EssenceBody = &AccessListEssence{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,45 @@
+package ledgerstate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessList_AddContains(t *testing.T) {
+	var outputID1, outputID2, outputID3 OutputID
+	outputID1[0] = 1
+	outputID2[0] = 2
+	outputID3[0] = 3
+
+	var accessList AccessList
+	accessList.Add(outputID1)
+	accessList.Add(outputID2)
+	accessList.Add(outputID1)
+
+	assert.True(t, accessList.Contains(outputID1))
+	assert.True(t, accessList.Contains(outputID2))
+	assert.False(t, accessList.Contains(outputID3))
+	assert.Len(t, accessList, 2)
+}
+
+func TestAccessListEssence_ValidateAccessList(t *testing.T) {
+	var declaredOutputID, undeclaredOutputID OutputID
+	declaredOutputID[0] = 1
+	undeclaredOutputID[0] = 2
+
+	var declaredAddress, undeclaredAddress Address
+	declaredAddress[0] = 1
+	undeclaredAddress[0] = 2
+
+	accessList := AccessList{declaredOutputID}
+	essence := NewAccessListEssence(nil, nil, nil, accessList, []Address{declaredAddress})
+
+	assert.NoError(t, essence.ValidateAccessList([]OutputID{declaredOutputID}, []Address{declaredAddress}))
+
+	err := essence.ValidateAccessList([]OutputID{undeclaredOutputID}, nil)
+	assert.ErrorIs(t, err, ErrAccessListViolation)
+
+	err = essence.ValidateAccessList(nil, []Address{undeclaredAddress})
+	assert.ErrorIs(t, err, ErrAccessListViolation)
+}
@@ -0,0 +1,177 @@
+package ledgerstate
+
+import (
+	"crypto/sha256"
+	"sync"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"golang.org/x/xerrors"
+)
+
+// region Signer ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Signer abstracts the rules that decide what gets hashed and signed for a TransactionEssence, so that mixing a
+// NetworkID into the preimage (to stop a testnet's signed essences from also verifying on mainnet, for example) is a
+// choice of which Signer to use rather than a change to the hashing call sites themselves. Nothing in this package
+// wires a configured Signer into real unlock-block verification or message processing yet, so this abstraction has
+// no effect on transactions processed today - that integration is a separate, future change.
+type Signer interface {
+	// Hash returns the preimage that UnlockBlocks are expected to sign over for essence.
+	Hash(essence *TransactionEssence) [32]byte
+
+	// Sender recovers the identity.ID that produced the UnlockBlock at unlockIndex in tx, according to this Signer's
+	// Hash.
+	Sender(tx *Transaction, unlockIndex int) (identity.ID, error)
+
+	// NetworkID returns the network identifier that this Signer binds into its Hash, or 0 for the legacy Signer.
+	NetworkID() uint64
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region legacySigner /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// legacySigner hashes an essence exactly like the original, network-agnostic code did (a plain hash of the essence
+// bytes), so that Transactions signed before replay protection was introduced keep verifying.
+type legacySigner struct{}
+
+// NewLegacySigner creates a Signer whose Hash is network-agnostic, for backward compatibility with Transactions that
+// were signed before network-bound replay protection existed.
+func NewLegacySigner() Signer {
+	return legacySigner{}
+}
+
+// Hash returns sha256(essence.Bytes()).
+func (legacySigner) Hash(essence *TransactionEssence) [32]byte {
+	return sha256.Sum256(essence.Bytes())
+}
+
+// Sender recovers the identity.ID that produced the UnlockBlock at unlockIndex in tx.
+func (s legacySigner) Sender(tx *Transaction, unlockIndex int) (identity.ID, error) {
+	return senderFromUnlockBlock(s, tx, unlockIndex)
+}
+
+// NetworkID returns 0, since the legacy Signer does not bind a network identifier into its Hash.
+func (legacySigner) NetworkID() uint64 {
+	return 0
+}
+
+// code contract (make sure the struct implements all required methods)
+var _ Signer = legacySigner{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region replayProtectedSigner ///////////////////////////////////////////////////////////////////////////////////////
+
+// replayProtectedSigner mixes a NetworkID into the hashed preimage so that an essence signed for one network cannot
+// be replayed on another.
+type replayProtectedSigner struct {
+	networkID uint64
+}
+
+// NewReplayProtectedSigner creates a Signer that binds networkID into every Hash it produces.
+func NewReplayProtectedSigner(networkID uint64) Signer {
+	return replayProtectedSigner{networkID: networkID}
+}
+
+// Hash returns sha256(essence.Bytes() || networkID), binding the Signer's NetworkID into the preimage.
+func (s replayProtectedSigner) Hash(essence *TransactionEssence) [32]byte {
+	preimage := marshalutil.New(marshalutil.UINT64_SIZE).WriteUint64(s.networkID).Bytes()
+	preimage = append(essence.Bytes(), preimage...)
+
+	return sha256.Sum256(preimage)
+}
+
+// Sender recovers the identity.ID that produced the UnlockBlock at unlockIndex in tx.
+func (s replayProtectedSigner) Sender(tx *Transaction, unlockIndex int) (identity.ID, error) {
+	return senderFromUnlockBlock(s, tx, unlockIndex)
+}
+
+// NetworkID returns the network identifier that this Signer binds into its Hash.
+func (s replayProtectedSigner) NetworkID() uint64 {
+	return s.networkID
+}
+
+// code contract (make sure the struct implements all required methods)
+var _ Signer = replayProtectedSigner{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Params ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Params bundles the network wide configuration values that LatestSigner needs to pick the correct Signer.
+type Params struct {
+	// NetworkID uniquely identifies the network that a node is participating in (e.g. mainnet vs. a devnet).
+	NetworkID uint64
+}
+
+// LatestSigner returns the most recent Signer for the network described by params.
+func LatestSigner(params *Params) Signer {
+	return LatestSignerForNetworkID(params.NetworkID)
+}
+
+// LatestSignerForNetworkID returns the most recent Signer for networkID.
+func LatestSignerForNetworkID(networkID uint64) Signer {
+	return NewReplayProtectedSigner(networkID)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region UnlockBlockVerifier //////////////////////////////////////////////////////////////////////////////////////////
+
+// ErrInvalidUnlockIndex is returned by Signer.Sender when unlockIndex does not address an UnlockBlock of the
+// Transaction.
+var ErrInvalidUnlockIndex = xerrors.New("unlock index does not address an UnlockBlock of the Transaction")
+
+// UnlockBlockVerifierFunc recovers the identity.ID that produced the UnlockBlock at unlockIndex, given the essence
+// hash it is expected to sign over. The concrete signature scheme checks (ED25519, BLS, ...) live with the
+// UnlockBlock types themselves; RegisterUnlockBlockVerifier lets that code plug into Signer.Sender the same way
+// RegisterTransactionEssenceType lets an EssenceBody plug into TransactionEssenceFromMarshalUtil.
+type UnlockBlockVerifierFunc func(essenceHash [32]byte, unlockBlocks UnlockBlocks, unlockIndex int) (identity.ID, error)
+
+var (
+	// unlockBlockVerifier is the currently registered UnlockBlockVerifierFunc.
+	unlockBlockVerifier UnlockBlockVerifierFunc
+
+	// unlockBlockVerifierMutex protects unlockBlockVerifier against concurrent access.
+	unlockBlockVerifierMutex sync.RWMutex
+)
+
+// RegisterUnlockBlockVerifier registers the UnlockBlockVerifierFunc that every Signer implementation delegates to in
+// order to turn a hashed essence plus an unlock index back into the identity.ID that signed it.
+func RegisterUnlockBlockVerifier(verifier UnlockBlockVerifierFunc) {
+	unlockBlockVerifierMutex.Lock()
+	defer unlockBlockVerifierMutex.Unlock()
+
+	unlockBlockVerifier = verifier
+}
+
+// senderFromUnlockBlock hashes tx.Essence() with signer and delegates to the registered UnlockBlockVerifierFunc to
+// recover the identity.ID that produced the UnlockBlock at unlockIndex. It is shared by every Signer implementation
+// so that they only ever differ in how they compute Hash.
+func senderFromUnlockBlock(signer Signer, tx *Transaction, unlockIndex int) (sender identity.ID, err error) {
+	unlockBlocks := tx.UnlockBlocks()
+	if unlockIndex < 0 || unlockIndex >= len(unlockBlocks) {
+		err = xerrors.Errorf("failed to resolve UnlockBlock %d: %w", unlockIndex, ErrInvalidUnlockIndex)
+		return
+	}
+
+	unlockBlockVerifierMutex.RLock()
+	verifier := unlockBlockVerifier
+	unlockBlockVerifierMutex.RUnlock()
+
+	if verifier == nil {
+		err = xerrors.Errorf("failed to resolve UnlockBlock %d: no UnlockBlockVerifierFunc was registered via RegisterUnlockBlockVerifier", unlockIndex)
+		return
+	}
+
+	if sender, err = verifier(signer.Hash(tx.Essence()), unlockBlocks, unlockIndex); err != nil {
+		err = xerrors.Errorf("failed to recover sender of UnlockBlock %d: %w", unlockIndex, err)
+		return
+	}
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
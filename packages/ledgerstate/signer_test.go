@@ -0,0 +1,62 @@
+package ledgerstate
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+)
+
+// stubEssenceBody is a minimal EssenceBody used to exercise Signer without depending on the concrete Inputs/Outputs
+// types that make up the rest of the ledgerstate package.
+type stubEssenceBody struct {
+	data []byte
+}
+
+func (s *stubEssenceBody) Inputs() Inputs {
+	return nil
+}
+
+func (s *stubEssenceBody) Outputs() Outputs {
+	return nil
+}
+
+func (s *stubEssenceBody) Payload() payload.Payload {
+	return nil
+}
+
+func (s *stubEssenceBody) Bytes() []byte {
+	return s.data
+}
+
+var _ EssenceBody = &stubEssenceBody{}
+
+func TestLegacySigner_Hash(t *testing.T) {
+	essence := NewTransactionEssenceWithBody(LegacyTransactionEssenceVersion, &stubEssenceBody{data: []byte("essence")})
+
+	signer := NewLegacySigner()
+
+	assert.Equal(t, sha256.Sum256(essence.Bytes()), signer.Hash(essence))
+	assert.Equal(t, uint64(0), signer.NetworkID())
+}
+
+func TestReplayProtectedSigner_Hash(t *testing.T) {
+	essence := NewTransactionEssenceWithBody(LegacyTransactionEssenceVersion, &stubEssenceBody{data: []byte("essence")})
+
+	mainnetSigner := NewReplayProtectedSigner(1)
+	testnetSigner := NewReplayProtectedSigner(2)
+
+	assert.Equal(t, uint64(1), mainnetSigner.NetworkID())
+	assert.NotEqual(t, mainnetSigner.Hash(essence), testnetSigner.Hash(essence))
+	assert.NotEqual(t, NewLegacySigner().Hash(essence), mainnetSigner.Hash(essence))
+}
+
+func TestSender_InvalidUnlockIndex(t *testing.T) {
+	essence := NewTransactionEssenceWithBody(LegacyTransactionEssenceVersion, &stubEssenceBody{data: []byte("essence")})
+	tx := NewTransaction(essence, nil)
+
+	_, err := NewLegacySigner().Sender(tx, 0)
+	assert.ErrorIs(t, err, ErrInvalidUnlockIndex)
+}
@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"fmt"
 	"strconv"
+	"sync"
 
 	"github.com/iotaledger/goshimmer/packages/cerrors"
 	"github.com/iotaledger/goshimmer/packages/tangle/payload"
@@ -115,7 +116,7 @@ type Transaction struct {
 // NewTransaction create a new Transaction from the given details.
 func NewTransaction(essence *TransactionEssence, unlockBlocks UnlockBlocks) *Transaction {
 	if len(unlockBlocks) != len(essence.Inputs()) {
-		panic(fmt.Sprintf("amount of UnlockBlocks (%d) does not match amount of Inputs (%d)", len(unlockBlocks), len(essence.inputs)))
+		panic(fmt.Sprintf("amount of UnlockBlocks (%d) does not match amount of Inputs (%d)", len(unlockBlocks), len(essence.Inputs())))
 	}
 
 	return &Transaction{
@@ -172,7 +173,7 @@ func TransactionFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (transacti
 	}
 
 	if len(transaction.unlockBlocks) != len(transaction.essence.Inputs()) {
-		err = xerrors.Errorf("amount of UnlockBlocks (%d) does not match amount of Inputs (%d): %w", len(transaction.unlockBlocks), len(transaction.essence.inputs), cerrors.ParseBytesFailed)
+		err = xerrors.Errorf("amount of UnlockBlocks (%d) does not match amount of Inputs (%d): %w", len(transaction.unlockBlocks), len(transaction.essence.Inputs()), cerrors.ParseBytesFailed)
 		return
 	}
 
@@ -220,21 +221,171 @@ var _ payload.Payload = &Transaction{}
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
-// region TransactionEssence ///////////////////////////////////////////////////////////////////////////////////////////
+// region EssenceBody /////////////////////////////////////////////////////////////////////////////////////////////
 
-// TransactionEssence contains the relevant information of the transfer (without the unlocking information).
-type TransactionEssence struct {
-	version TransactionEssenceVersion
+// EssenceBody represents the version specific part of a TransactionEssence. Every TransactionEssenceVersion is
+// backed by exactly one EssenceBody implementation that knows how to parse and serialize its own layout, which
+// allows new essence variants (e.g. access-listed, delegated or fee-market essences) to be introduced without
+// touching the envelope that wraps them.
+type EssenceBody interface {
+	// Inputs returns the Inputs that are consumed by the EssenceBody.
+	Inputs() Inputs
+
+	// Outputs returns the Outputs that are created by the EssenceBody.
+	Outputs() Outputs
+
+	// Payload returns the optional Payload that is attached to the EssenceBody.
+	Payload() payload.Payload
+
+	// Bytes returns a marshaled version of the EssenceBody (without the leading TransactionEssenceVersion).
+	Bytes() []byte
+}
+
+// EssenceBodyFromMarshalUtilFunc parses the version specific part of a TransactionEssence from a MarshalUtil. It is
+// registered per TransactionEssenceVersion via RegisterTransactionEssenceType.
+type EssenceBodyFromMarshalUtilFunc func(marshalUtil *marshalutil.MarshalUtil) (EssenceBody, error)
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region essence type registry ///////////////////////////////////////////////////////////////////////////////////////
+
+// ErrUnknownEssenceType is returned when a TransactionEssence is parsed with a TransactionEssenceVersion that is
+// neither the LegacyTransactionEssenceVersion nor a version registered via RegisterTransactionEssenceType.
+// It wraps cerrors.ParseBytesFailed, so errors.Is matches both.
+var ErrUnknownEssenceType = xerrors.Errorf("unknown TransactionEssenceVersion: %w", cerrors.ParseBytesFailed)
+
+var (
+	// essenceTypeRegistry contains the decoders for every registered non-legacy TransactionEssenceVersion.
+	essenceTypeRegistry = make(map[TransactionEssenceVersion]EssenceBodyFromMarshalUtilFunc)
+
+	// essenceTypeRegistryMutex protects essenceTypeRegistry against concurrent access.
+	essenceTypeRegistryMutex sync.RWMutex
+)
+
+// RegisterTransactionEssenceType registers a decoder for a TransactionEssenceVersion in
+// [MinTypedTransactionEssenceVersion, MaxTypedTransactionEssenceVersion] so that TransactionEssenceFromMarshalUtil is
+// able to parse it. It is meant to be called from an init function and panics if version lies outside of the
+// reserved range or was already registered, since both are considered programming errors.
+func RegisterTransactionEssenceType(version TransactionEssenceVersion, decoder EssenceBodyFromMarshalUtilFunc) {
+	if version < MinTypedTransactionEssenceVersion || version > MaxTypedTransactionEssenceVersion {
+		panic(fmt.Sprintf("TransactionEssenceVersion(%d) is outside of the registrable range [%d, %d]", version, MinTypedTransactionEssenceVersion, MaxTypedTransactionEssenceVersion))
+	}
+
+	essenceTypeRegistryMutex.Lock()
+	defer essenceTypeRegistryMutex.Unlock()
+
+	if _, exists := essenceTypeRegistry[version]; exists {
+		panic(fmt.Sprintf("TransactionEssenceVersion(%d) was already registered", version))
+	}
+
+	essenceTypeRegistry[version] = decoder
+}
+
+// essenceBodyDecoder returns the decoder that was registered for version and whether it exists.
+func essenceBodyDecoder(version TransactionEssenceVersion) (decoder EssenceBodyFromMarshalUtilFunc, exists bool) {
+	essenceTypeRegistryMutex.RLock()
+	defer essenceTypeRegistryMutex.RUnlock()
+
+	decoder, exists = essenceTypeRegistry[version]
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region legacyEssenceBody ////////////////////////////////////////////////////////////////////////////////////////////
+
+// legacyEssenceBody implements EssenceBody for the LegacyTransactionEssenceVersion, i.e. the original hard-coded
+// Inputs/Outputs/Payload layout that predates the typed envelope.
+type legacyEssenceBody struct {
 	inputs  Inputs
 	outputs Outputs
 	payload payload.Payload
 }
 
-func NewTransactionEssence(version TransactionEssenceVersion, inputs Inputs, outputs Outputs) *TransactionEssence {
+// legacyEssenceBodyFromMarshalUtil unmarshals a legacyEssenceBody using a MarshalUtil (for easier unmarshaling).
+func legacyEssenceBodyFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (body EssenceBody, err error) {
+	parsedBody := &legacyEssenceBody{}
+	if parsedBody.inputs, err = InputsFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse Inputs from MarshalUtil: %w", err)
+		return
+	}
+	if parsedBody.outputs, err = OutputsFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse Outputs from MarshalUtil: %w", err)
+		return
+	}
+	if parsedBody.payload, err = payload.FromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse Payload from MarshalUtil: %w", err)
+		return
+	}
+	body = parsedBody
+
+	return
+}
+
+// Inputs returns the Inputs of the legacyEssenceBody.
+func (b *legacyEssenceBody) Inputs() Inputs {
+	return b.inputs
+}
+
+// Outputs returns the Outputs of the legacyEssenceBody.
+func (b *legacyEssenceBody) Outputs() Outputs {
+	return b.outputs
+}
+
+// Payload returns the Payload of the legacyEssenceBody.
+func (b *legacyEssenceBody) Payload() payload.Payload {
+	return b.payload
+}
+
+// Bytes returns a marshaled version of the legacyEssenceBody.
+func (b *legacyEssenceBody) Bytes() []byte {
+	marshalUtil := marshalutil.New().
+		Write(b.inputs).
+		Write(b.outputs)
+
+	if !typeutils.IsInterfaceNil(b.payload) {
+		marshalUtil.Write(b.payload)
+	} else {
+		marshalUtil.WriteUint32(0)
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// code contract (make sure the struct implements all required methods)
+var _ EssenceBody = &legacyEssenceBody{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region TransactionEssence ///////////////////////////////////////////////////////////////////////////////////////////
+
+// TransactionEssence contains the relevant information of the transfer (without the unlocking information). Its
+// TransactionEssenceVersion selects which EssenceBody implementation backs it, analogous to how an EIP-2718 typed
+// transaction envelope selects its payload by a leading type byte.
+type TransactionEssence struct {
+	version TransactionEssenceVersion
+	body    EssenceBody
+}
+
+// NewTransactionEssence creates a new TransactionEssence with the LegacyTransactionEssenceVersion layout from the
+// given details. Use NewTransactionEssenceWithBody to create a TransactionEssence for any other, registered
+// TransactionEssenceVersion.
+func NewTransactionEssence(inputs Inputs, outputs Outputs) *TransactionEssence {
+	return &TransactionEssence{
+		version: LegacyTransactionEssenceVersion,
+		body: &legacyEssenceBody{
+			inputs:  inputs,
+			outputs: outputs,
+		},
+	}
+}
+
+// NewTransactionEssenceWithBody creates a new TransactionEssence for a registered, non-legacy EssenceBody.
+func NewTransactionEssenceWithBody(version TransactionEssenceVersion, body EssenceBody) *TransactionEssence {
 	return &TransactionEssence{
 		version: version,
-		inputs:  inputs,
-		outputs: outputs,
+		body:    body,
 	}
 }
 
@@ -250,60 +401,71 @@ func TransactionEssenceFromBytes(bytes []byte) (transactionEssence *TransactionE
 	return
 }
 
-// TransactionEssenceFromMarshalUtil unmarshals a TransactionEssence using a MarshalUtil (for easier unmarshaling).
+// TransactionEssenceFromMarshalUtil unmarshals a TransactionEssence using a MarshalUtil (for easier unmarshaling). It
+// dispatches to the legacy layout or to the EssenceBody registered for the parsed TransactionEssenceVersion via
+// RegisterTransactionEssenceType, failing with ErrUnknownEssenceType for anything else.
 func TransactionEssenceFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (transactionEssence *TransactionEssence, err error) {
 	transactionEssence = &TransactionEssence{}
 	if transactionEssence.version, err = TransactionEssenceVersionFromMarshalUtil(marshalUtil); err != nil {
 		err = xerrors.Errorf("failed to parse TransactionEssenceVersion from MarshalUtil: %w", err)
 		return
 	}
-	if transactionEssence.inputs, err = InputsFromMarshalUtil(marshalUtil); err != nil {
-		err = xerrors.Errorf("failed to parse Inputs from MarshalUtil: %w", err)
+
+	if transactionEssence.version == LegacyTransactionEssenceVersion {
+		if transactionEssence.body, err = legacyEssenceBodyFromMarshalUtil(marshalUtil); err != nil {
+			err = xerrors.Errorf("failed to parse legacy EssenceBody from MarshalUtil: %w", err)
+			return
+		}
+
 		return
 	}
-	if transactionEssence.outputs, err = OutputsFromMarshalUtil(marshalUtil); err != nil {
-		err = xerrors.Errorf("failed to parse Outputs from MarshalUtil: %w", err)
+
+	decoder, exists := essenceBodyDecoder(transactionEssence.version)
+	if !exists {
+		err = xerrors.Errorf("failed to parse TransactionEssence with version %d: %w", transactionEssence.version, ErrUnknownEssenceType)
 		return
 	}
-	if transactionEssence.payload, err = payload.FromMarshalUtil(marshalUtil); err != nil {
-		err = xerrors.Errorf("failed to parse Payload from MarshalUtil: %w", err)
+	if transactionEssence.body, err = decoder(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse EssenceBody from MarshalUtil: %w", err)
 		return
 	}
 
 	return
 }
 
+// Version returns the TransactionEssenceVersion of the TransactionEssence.
+func (t *TransactionEssence) Version() TransactionEssenceVersion {
+	return t.version
+}
+
+// Body returns the EssenceBody that backs the TransactionEssence.
+func (t *TransactionEssence) Body() EssenceBody {
+	return t.body
+}
+
 // Inputs returns the Inputs of the TransactionEssence.
 func (t *TransactionEssence) Inputs() Inputs {
-	return t.inputs
+	return t.body.Inputs()
 }
 
 func (t *TransactionEssence) Outputs() Outputs {
-	return t.outputs
+	return t.body.Outputs()
 }
 
 // Bytes returns a marshaled version of the TransactionEssence.
 func (t *TransactionEssence) Bytes() []byte {
-	marshalUtil := marshalutil.New().
+	return marshalutil.New().
 		Write(t.version).
-		Write(t.inputs).
-		Write(t.outputs)
-
-	if !typeutils.IsInterfaceNil(t.payload) {
-		marshalUtil.Write(t.payload)
-	} else {
-		marshalUtil.WriteUint32(0)
-	}
-
-	return marshalUtil.Bytes()
+		WriteBytes(t.body.Bytes()).
+		Bytes()
 }
 
 func (t *TransactionEssence) String() string {
 	return stringify.Struct("TransactionEssence",
 		stringify.StructField("version", t.version),
-		stringify.StructField("inputs", t.inputs),
-		stringify.StructField("outputs", t.outputs),
-		stringify.StructField("payload", t.payload),
+		stringify.StructField("inputs", t.body.Inputs()),
+		stringify.StructField("outputs", t.body.Outputs()),
+		stringify.StructField("payload", t.body.Payload()),
 	)
 }
 
@@ -311,9 +473,25 @@ func (t *TransactionEssence) String() string {
 
 // region TransactionEssenceVersion ////////////////////////////////////////////////////////////////////////////////////
 
-// TransactionEssenceVersion represents a byte denoting a version augmented with some additional logic.
+// TransactionEssenceVersion represents a byte denoting a version augmented with some additional logic. Version 0
+// (LegacyTransactionEssenceVersion) is reserved for the original essence layout, while versions in
+// [MinTypedTransactionEssenceVersion, MaxTypedTransactionEssenceVersion] select an EssenceBody that was registered
+// via RegisterTransactionEssenceType, mirroring how EIP-2718 reserves a typed range of transaction envelopes.
 type TransactionEssenceVersion uint8
 
+const (
+	// LegacyTransactionEssenceVersion is the TransactionEssenceVersion of the original, hard-coded essence layout.
+	LegacyTransactionEssenceVersion TransactionEssenceVersion = 0
+
+	// MinTypedTransactionEssenceVersion is the first TransactionEssenceVersion that can be registered via
+	// RegisterTransactionEssenceType.
+	MinTypedTransactionEssenceVersion TransactionEssenceVersion = 0x01
+
+	// MaxTypedTransactionEssenceVersion is the last TransactionEssenceVersion that can be registered via
+	// RegisterTransactionEssenceType.
+	MaxTypedTransactionEssenceVersion TransactionEssenceVersion = 0x7f
+)
+
 // TransactionEssenceVersionFromBytes unmarshals a TransactionEssenceVersion from a sequence of bytes.
 func TransactionEssenceVersionFromBytes(bytes []byte) (version TransactionEssenceVersion, consumedBytes int, err error) {
 	marshalUtil := marshalutil.New(bytes)
@@ -327,17 +505,14 @@ func TransactionEssenceVersionFromBytes(bytes []byte) (version TransactionEssenc
 }
 
 // TransactionEssenceVersionFromMarshalUtil unmarshals a TransactionEssenceVersion using a MarshalUtil (for easier
-// unmarshaling).
+// unmarshaling). It only reads the raw discriminator byte - whether the resulting version is actually known is
+// decided by TransactionEssenceFromMarshalUtil, which consults the essence type registry.
 func TransactionEssenceVersionFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (version TransactionEssenceVersion, err error) {
 	readByte, err := marshalUtil.ReadByte()
 	if err != nil {
 		err = xerrors.Errorf("failed to parse version TransactionEssenceVersion: %w", err)
 		return
 	}
-	if readByte != 0 {
-		err = xerrors.Errorf("failed to parse version TransactionEssenceVersion: %w", err)
-		return
-	}
 	version = TransactionEssenceVersion(readByte)
 
 	return
@@ -0,0 +1,49 @@
+package ledgerstate
+
+import (
+	"testing"
+
+	"github.com/iotaledger/goshimmer/packages/cerrors"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionEssence_LegacyRoundTrip(t *testing.T) {
+	essence := NewTransactionEssence(Inputs{}, Outputs{})
+
+	restored, err := TransactionEssenceFromMarshalUtil(marshalutil.New(essence.Bytes()))
+	require.NoError(t, err)
+
+	assert.Equal(t, LegacyTransactionEssenceVersion, restored.Version())
+	assert.Equal(t, essence.Inputs(), restored.Inputs())
+	assert.Equal(t, essence.Outputs(), restored.Outputs())
+}
+
+func TestTransactionEssenceFromMarshalUtil_UnknownVersion(t *testing.T) {
+	bytes := marshalutil.New().
+		Write(MaxTypedTransactionEssenceVersion).
+		Bytes()
+
+	_, err := TransactionEssenceFromMarshalUtil(marshalutil.New(bytes))
+	assert.ErrorIs(t, err, ErrUnknownEssenceType)
+	assert.ErrorIs(t, err, cerrors.ParseBytesFailed)
+}
+
+func TestRegisterTransactionEssenceType_OutOfRange(t *testing.T) {
+	assert.Panics(t, func() {
+		RegisterTransactionEssenceType(LegacyTransactionEssenceVersion, legacyEssenceBodyFromMarshalUtil)
+	})
+	assert.Panics(t, func() {
+		RegisterTransactionEssenceType(MaxTypedTransactionEssenceVersion+1, legacyEssenceBodyFromMarshalUtil)
+	})
+}
+
+func TestRegisterTransactionEssenceType_DoubleRegistration(t *testing.T) {
+	version := MaxTypedTransactionEssenceVersion
+	RegisterTransactionEssenceType(version, legacyEssenceBodyFromMarshalUtil)
+
+	assert.Panics(t, func() {
+		RegisterTransactionEssenceType(version, legacyEssenceBodyFromMarshalUtil)
+	})
+}
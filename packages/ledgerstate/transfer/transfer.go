@@ -1,14 +1,39 @@
 package transfer
 
 import (
+	"bytes"
+	"sort"
+
 	"github.com/iotaledger/goshimmer/packages/binary/address"
+	"github.com/iotaledger/goshimmer/packages/binary/structcodec"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate/coloredcoins"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"golang.org/x/xerrors"
 )
 
+// init registers the decoders that the structcodec tagged fields below rely on, since the reflection based
+// structcodec.Marshal/Unmarshal has no other way to reach a FromMarshalUtil/FromBytes function that was not
+// compiled into the running binary.
+func init() {
+	structcodec.RegisterMarshaler(Hash{}, func(data []byte) (value interface{}, consumedBytes int, err error) {
+		return HashFromBytes(data)
+	})
+	structcodec.RegisterMarshaler(&OutputReference{}, func(data []byte) (value interface{}, consumedBytes int, err error) {
+		return OutputReferenceFromBytes(data)
+	})
+}
+
+// Transfer is a single, self-contained value transfer: it consumes its inputs and distributes colored balances to
+// the addresses named in its outputs.
+//
+// hash and inputs are encoded via structcodec (hash has no `bin:"..."` tag and goes through its Bytes() and the
+// decoder registered above, inputs through its `bin:"len=uint32"` tag); outputs is a map and therefore
+// outside of what structcodec's tag vocabulary can express (the same limitation that go-ethereum's rlpstruct has
+// for Go maps), so MarshalBinary/UnmarshalBinary encode/decode it by hand.
 type Transfer struct {
 	hash    Hash
-	inputs  []*OutputReference
-	outputs map[address.Address][]*coloredcoins.ColoredBalance
+	inputs  []*OutputReference                                 `bin:"len=uint32"`
+	outputs map[address.Address][]*coloredcoins.ColoredBalance `bin:"-"`
 }
 
 func NewTransfer(transferHash Hash) *Transfer {
@@ -47,10 +72,96 @@ func (transfer *Transfer) GetOutputs() map[address.Address][]*coloredcoins.Color
 	return transfer.outputs
 }
 
+// MarshalBinary marshals the Transfer into a sequence of bytes, satisfying encoding.BinaryMarshaler.
 func (transfer *Transfer) MarshalBinary() (data []byte, err error) {
+	taggedFields, err := structcodec.Marshal(transfer)
+	if err != nil {
+		err = xerrors.Errorf("failed to marshal Transfer: %w", err)
+		return
+	}
+
+	data = append(taggedFields, transfer.outputsBytes()...)
+
 	return
 }
 
+// UnmarshalBinary unmarshals the Transfer from a sequence of bytes, satisfying encoding.BinaryUnmarshaler.
 func (transfer *Transfer) UnmarshalBinary(data []byte) (err error) {
+	consumedBytes, err := structcodec.Unmarshal(data, transfer)
+	if err != nil {
+		err = xerrors.Errorf("failed to unmarshal Transfer: %w", err)
+		return
+	}
+
+	if transfer.outputs, err = outputsFromBytes(data[consumedBytes:]); err != nil {
+		err = xerrors.Errorf("failed to unmarshal Transfer outputs: %w", err)
+		return
+	}
+
+	return
+}
+
+// outputsBytes marshals the outputs map of the Transfer, sorting by Address so that the result is deterministic
+// even though Go map iteration order is not.
+func (transfer *Transfer) outputsBytes() []byte {
+	addresses := make([]address.Address, 0, len(transfer.outputs))
+	for addr := range transfer.outputs {
+		addresses = append(addresses, addr)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i].Bytes(), addresses[j].Bytes()) < 0
+	})
+
+	marshalUtil := marshalutil.New().WriteUint32(uint32(len(addresses)))
+	for _, addr := range addresses {
+		balances := transfer.outputs[addr]
+
+		marshalUtil.Write(addr).WriteUint32(uint32(len(balances)))
+		for _, balance := range balances {
+			marshalUtil.Write(balance)
+		}
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// outputsFromBytes unmarshals the outputs map of the Transfer from data.
+func outputsFromBytes(data []byte) (outputs map[address.Address][]*coloredcoins.ColoredBalance, err error) {
+	marshalUtil := marshalutil.New(data)
+
+	addressCount, err := marshalUtil.ReadUint32()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse address count: %w", err)
+		return
+	}
+
+	outputs = make(map[address.Address][]*coloredcoins.ColoredBalance, addressCount)
+	for i := uint32(0); i < addressCount; i++ {
+		addr, addrErr := address.AddressFromMarshalUtil(marshalUtil)
+		if addrErr != nil {
+			err = xerrors.Errorf("failed to parse Address: %w", addrErr)
+			return
+		}
+
+		balanceCount, balanceCountErr := marshalUtil.ReadUint32()
+		if balanceCountErr != nil {
+			err = xerrors.Errorf("failed to parse balance count: %w", balanceCountErr)
+			return
+		}
+
+		balances := make([]*coloredcoins.ColoredBalance, 0, balanceCount)
+		for j := uint32(0); j < balanceCount; j++ {
+			balance, balanceErr := coloredcoins.ColoredBalanceFromMarshalUtil(marshalUtil)
+			if balanceErr != nil {
+				err = xerrors.Errorf("failed to parse ColoredBalance: %w", balanceErr)
+				return
+			}
+
+			balances = append(balances, balance)
+		}
+
+		outputs[addr] = balances
+	}
+
 	return
 }
@@ -0,0 +1,29 @@
+package transfer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTransfer_MarshalUnmarshalBinary round-trips a Transfer through MarshalBinary/UnmarshalBinary, covering hash and
+// inputs - the two fields that are unexported relative to structcodec and therefore only marshal correctly now that
+// Marshal/Unmarshal resolve field addresses via unsafe.Pointer. outputs is left empty here, since address.Address
+// and coloredcoins.ColoredBalance are outside of this package and have no constructor available to this test; the
+// outputsBytes/outputsFromBytes path is still exercised, just with a zero-length map.
+func TestTransfer_MarshalUnmarshalBinary(t *testing.T) {
+	original := NewTransfer(Hash{})
+	original.AddInput(&OutputReference{})
+	original.AddInput(&OutputReference{})
+
+	data, err := original.MarshalBinary()
+	require.NoError(t, err)
+
+	decoded := &Transfer{}
+	require.NoError(t, decoded.UnmarshalBinary(data))
+
+	assert.Equal(t, original.GetHash(), decoded.GetHash())
+	assert.Equal(t, original.GetInputs(), decoded.GetInputs())
+	assert.Equal(t, original.GetOutputs(), decoded.GetOutputs())
+}
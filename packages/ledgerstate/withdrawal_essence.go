@@ -0,0 +1,265 @@
+package ledgerstate
+
+import (
+	"github.com/iotaledger/goshimmer/packages/cerrors"
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/stringify"
+	"github.com/iotaledger/hive.go/typeutils"
+	"golang.org/x/xerrors"
+)
+
+// region WithdrawalEssenceVersion /////////////////////////////////////////////////////////////////////////////////////
+
+// WithdrawalEssenceVersion is the TransactionEssenceVersion that WithdrawalEssence is registered under.
+const WithdrawalEssenceVersion TransactionEssenceVersion = MinTypedTransactionEssenceVersion + 1
+
+// init registers WithdrawalEssence with the typed-envelope registry introduced for TransactionEssence.
+func init() {
+	RegisterTransactionEssenceType(WithdrawalEssenceVersion, WithdrawalEssenceFromMarshalUtil)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Withdrawal ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Withdrawal credits Amount of Color to Address on behalf of Validator, without requiring a corresponding Input. It
+// is meant to be applied after the normal Inputs and Outputs of its TransactionEssence, with the credited amount
+// coming out of a protocol-controlled mana escrow balance managed by the consensus layer (e.g. delegated-mana
+// redemption or slashing refunds) instead of the ledger; wiring that application into a booker is not part of this
+// change.
+type Withdrawal struct {
+	// Index is the position of the Withdrawal within its WithdrawalEssence's Withdrawals list. It must be
+	// monotonically increasing and gap-free so that a WithdrawalEssence has a single, deterministic byte
+	// representation.
+	Index uint64
+
+	// Validator is the identity that the escrow debit is attributed to.
+	Validator identity.ID
+
+	// Address is the recipient of the withdrawn funds.
+	Address Address
+
+	// Amount is the number of Color tokens credited to Address.
+	Amount uint64
+
+	// Color is the token color credited to Address.
+	Color Color
+}
+
+// Bytes returns a marshaled version of the Withdrawal.
+func (w Withdrawal) Bytes() []byte {
+	return marshalutil.New().
+		WriteUint64(w.Index).
+		Write(w.Validator).
+		Write(w.Address).
+		WriteUint64(w.Amount).
+		Write(w.Color).
+		Bytes()
+}
+
+// WithdrawalFromMarshalUtil unmarshals a Withdrawal using a MarshalUtil (for easier unmarshaling).
+func WithdrawalFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (withdrawal Withdrawal, err error) {
+	if withdrawal.Index, err = marshalUtil.ReadUint64(); err != nil {
+		err = xerrors.Errorf("failed to parse Withdrawal Index from MarshalUtil: %w", err)
+		return
+	}
+	if withdrawal.Validator, err = identity.IDFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse Withdrawal Validator from MarshalUtil: %w", err)
+		return
+	}
+	if withdrawal.Address, err = AddressFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse Withdrawal Address from MarshalUtil: %w", err)
+		return
+	}
+	if withdrawal.Amount, err = marshalUtil.ReadUint64(); err != nil {
+		err = xerrors.Errorf("failed to parse Withdrawal Amount from MarshalUtil: %w", err)
+		return
+	}
+	if withdrawal.Color, err = ColorFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse Withdrawal Color from MarshalUtil: %w", err)
+		return
+	}
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region WithdrawalEssence ////////////////////////////////////////////////////////////////////////////////////////////
+
+// ErrWithdrawalOrderViolation is returned while parsing a WithdrawalEssence whose Withdrawals are not strictly
+// ordered by Index, either because an Index was skipped or because it appears more than once. It wraps
+// cerrors.ParseBytesFailed, so errors.Is matches both.
+var ErrWithdrawalOrderViolation = xerrors.Errorf("withdrawal index is out of order or duplicated: %w", cerrors.ParseBytesFailed)
+
+// ErrWithdrawalOutputFactoryNotRegistered is returned by OutputsWithWithdrawals when the WithdrawalEssence carries
+// Withdrawals but no factory was registered via RegisterWithdrawalOutputFactory to turn them into synthetic Outputs.
+var ErrWithdrawalOutputFactoryNotRegistered = xerrors.New("no WithdrawalOutputFactory was registered via RegisterWithdrawalOutputFactory")
+
+// WithdrawalEssence is an EssenceBody that augments the legacy Inputs/Outputs/Payload layout with a Withdrawals list
+// meant to be credited from a protocol-controlled mana escrow balance after the normal Inputs and Outputs are
+// applied. It is registered under WithdrawalEssenceVersion.
+type WithdrawalEssence struct {
+	inputs      Inputs
+	outputs     Outputs
+	payload     payload.Payload
+	withdrawals []Withdrawal
+}
+
+// NewWithdrawalEssence creates a new WithdrawalEssence from the given details. withdrawals must already be ordered
+// and gap-free by Index; use WithdrawalEssenceFromMarshalUtil to validate externally supplied data.
+func NewWithdrawalEssence(inputs Inputs, outputs Outputs, txPayload payload.Payload, withdrawals []Withdrawal) *WithdrawalEssence {
+	return &WithdrawalEssence{
+		inputs:      inputs,
+		outputs:     outputs,
+		payload:     txPayload,
+		withdrawals: withdrawals,
+	}
+}
+
+// WithdrawalEssenceFromMarshalUtil unmarshals a WithdrawalEssence using a MarshalUtil (for easier unmarshaling). Its
+// signature matches EssenceBodyFromMarshalUtilFunc so it can be passed directly to RegisterTransactionEssenceType.
+func WithdrawalEssenceFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (body EssenceBody, err error) {
+	essence := &WithdrawalEssence{}
+	if essence.inputs, err = InputsFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse Inputs from MarshalUtil: %w", err)
+		return
+	}
+	if essence.outputs, err = OutputsFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse Outputs from MarshalUtil: %w", err)
+		return
+	}
+	if essence.payload, err = payload.FromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse Payload from MarshalUtil: %w", err)
+		return
+	}
+
+	withdrawalsCount, err := marshalUtil.ReadUint32()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse Withdrawals length from MarshalUtil: %w", err)
+		return
+	}
+
+	essence.withdrawals = make([]Withdrawal, 0, withdrawalsCount)
+	for i := uint32(0); i < withdrawalsCount; i++ {
+		withdrawal, withdrawalErr := WithdrawalFromMarshalUtil(marshalUtil)
+		if withdrawalErr != nil {
+			err = xerrors.Errorf("failed to parse Withdrawal from MarshalUtil: %w", withdrawalErr)
+			return
+		}
+		if withdrawal.Index != uint64(i) {
+			err = xerrors.Errorf("withdrawal at position %d carries Index %d: %w", i, withdrawal.Index, ErrWithdrawalOrderViolation)
+			return
+		}
+
+		essence.withdrawals = append(essence.withdrawals, withdrawal)
+	}
+	body = essence
+
+	return
+}
+
+// Inputs returns the Inputs of the WithdrawalEssence.
+func (w *WithdrawalEssence) Inputs() Inputs {
+	return w.inputs
+}
+
+// Outputs returns the Outputs of the WithdrawalEssence. Withdrawals are deliberately not included here so that
+// legacy code keeps seeing the same Inputs()/Outputs() semantics it always has; use OutputsWithWithdrawals to opt
+// into seeing Withdrawals represented as synthetic Outputs.
+func (w *WithdrawalEssence) Outputs() Outputs {
+	return w.outputs
+}
+
+// Payload returns the Payload of the WithdrawalEssence.
+func (w *WithdrawalEssence) Payload() payload.Payload {
+	return w.payload
+}
+
+// Withdrawals returns the Withdrawals of the WithdrawalEssence, ordered by Index.
+func (w *WithdrawalEssence) Withdrawals() []Withdrawal {
+	return w.withdrawals
+}
+
+// EscrowDebit aggregates the Withdrawals by Color and returns, for every Color, the amount that must be debited from
+// the protocol-controlled mana escrow balance for the WithdrawalEssence to conserve funds. A booker's conservation
+// check can treat the returned amounts as an implicit Input per Color, in addition to the Inputs actually present on
+// the TransactionEssence.
+func (w *WithdrawalEssence) EscrowDebit() map[Color]uint64 {
+	debit := make(map[Color]uint64)
+	for _, withdrawal := range w.withdrawals {
+		debit[withdrawal.Color] += withdrawal.Amount
+	}
+
+	return debit
+}
+
+// withdrawalOutputFactory turns a Withdrawal into the synthetic Output that OutputsWithWithdrawals exposes for it.
+// It is registered by RegisterWithdrawalOutputFactory, since the concrete Output implementations live outside of
+// this part of the package.
+var withdrawalOutputFactory func(withdrawal Withdrawal) Output
+
+// RegisterWithdrawalOutputFactory registers the function that turns a Withdrawal into the synthetic Output that
+// OutputsWithWithdrawals exposes for callers that opt in to seeing Withdrawals as Outputs.
+func RegisterWithdrawalOutputFactory(factory func(withdrawal Withdrawal) Output) {
+	withdrawalOutputFactory = factory
+}
+
+// OutputsWithWithdrawals returns the Outputs of the WithdrawalEssence with every Withdrawal appended as a synthetic
+// Output (via the factory registered through RegisterWithdrawalOutputFactory), for legacy callers that opt in to
+// seeing Withdrawals through the Outputs() lens instead of handling them separately. It returns
+// ErrWithdrawalOutputFactoryNotRegistered if no factory was registered and the WithdrawalEssence actually carries
+// Withdrawals.
+func (w *WithdrawalEssence) OutputsWithWithdrawals() (outputs Outputs, err error) {
+	if len(w.withdrawals) == 0 {
+		return w.outputs, nil
+	}
+	if withdrawalOutputFactory == nil {
+		return nil, xerrors.Errorf("WithdrawalEssence carries %d Withdrawals: %w", len(w.withdrawals), ErrWithdrawalOutputFactoryNotRegistered)
+	}
+
+	outputs = make(Outputs, 0, len(w.outputs)+len(w.withdrawals))
+	outputs = append(outputs, w.outputs...)
+	for _, withdrawal := range w.withdrawals {
+		outputs = append(outputs, withdrawalOutputFactory(withdrawal))
+	}
+
+	return outputs, nil
+}
+
+// Bytes returns a marshaled version of the WithdrawalEssence.
+func (w *WithdrawalEssence) Bytes() []byte {
+	marshalUtil := marshalutil.New().
+		Write(w.inputs).
+		Write(w.outputs)
+
+	if !typeutils.IsInterfaceNil(w.payload) {
+		marshalUtil.Write(w.payload)
+	} else {
+		marshalUtil.WriteUint32(0)
+	}
+
+	marshalUtil.WriteUint32(uint32(len(w.withdrawals)))
+	for _, withdrawal := range w.withdrawals {
+		marshalUtil.WriteBytes(withdrawal.Bytes())
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// String returns a human readable version of the WithdrawalEssence.
+func (w *WithdrawalEssence) String() string {
+	return stringify.Struct("WithdrawalEssence",
+		stringify.StructField("inputs", w.inputs),
+		stringify.StructField("outputs", w.outputs),
+		stringify.StructField("payload", w.payload),
+		stringify.StructField("withdrawals", w.withdrawals),
+	)
+}
+
+// code contract (make sure the struct implements all required methods)
+var _ EssenceBody = &WithdrawalEssence{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
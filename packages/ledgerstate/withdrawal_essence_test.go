@@ -0,0 +1,87 @@
+package ledgerstate
+
+import (
+	"testing"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withdrawalEssenceBytesWithWithdrawals builds the byte representation that WithdrawalEssenceFromMarshalUtil parses,
+// with an empty Inputs/Outputs/Payload and the given Withdrawals written out verbatim, so that out-of-order or
+// duplicated Index values can be fed to it directly.
+func withdrawalEssenceBytesWithWithdrawals(withdrawals ...Withdrawal) []byte {
+	marshalUtil := marshalutil.New().
+		Write(Inputs{}).
+		Write(Outputs{}).
+		WriteUint32(0).
+		WriteUint32(uint32(len(withdrawals)))
+
+	for _, withdrawal := range withdrawals {
+		marshalUtil.WriteBytes(withdrawal.Bytes())
+	}
+
+	return marshalUtil.Bytes()
+}
+
+func TestWithdrawalEssenceFromMarshalUtil_OrderViolation(t *testing.T) {
+	skipped := withdrawalEssenceBytesWithWithdrawals(
+		Withdrawal{Index: 0},
+		Withdrawal{Index: 2},
+	)
+	_, err := WithdrawalEssenceFromMarshalUtil(marshalutil.New(skipped))
+	assert.ErrorIs(t, err, ErrWithdrawalOrderViolation)
+
+	duplicated := withdrawalEssenceBytesWithWithdrawals(
+		Withdrawal{Index: 0},
+		Withdrawal{Index: 0},
+	)
+	_, err = WithdrawalEssenceFromMarshalUtil(marshalutil.New(duplicated))
+	assert.ErrorIs(t, err, ErrWithdrawalOrderViolation)
+}
+
+func TestWithdrawalEssenceFromMarshalUtil_InOrder(t *testing.T) {
+	ordered := withdrawalEssenceBytesWithWithdrawals(
+		Withdrawal{Index: 0, Amount: 10},
+		Withdrawal{Index: 1, Amount: 20},
+	)
+	body, err := WithdrawalEssenceFromMarshalUtil(marshalutil.New(ordered))
+	require.NoError(t, err)
+
+	essence, ok := body.(*WithdrawalEssence)
+	require.True(t, ok)
+	assert.Len(t, essence.Withdrawals(), 2)
+}
+
+func TestWithdrawalEssence_OutputsWithWithdrawals_FactoryNotRegistered(t *testing.T) {
+	essence := NewWithdrawalEssence(nil, nil, nil, []Withdrawal{{Index: 0, Validator: identity.ID{}, Amount: 10}})
+
+	_, err := essence.OutputsWithWithdrawals()
+	assert.ErrorIs(t, err, ErrWithdrawalOutputFactoryNotRegistered)
+}
+
+func TestWithdrawalEssence_OutputsWithWithdrawals_NoWithdrawals(t *testing.T) {
+	essence := NewWithdrawalEssence(nil, nil, nil, nil)
+
+	outputs, err := essence.OutputsWithWithdrawals()
+	require.NoError(t, err)
+	assert.Equal(t, essence.Outputs(), outputs)
+}
+
+func TestWithdrawalEssence_EscrowDebit(t *testing.T) {
+	var colorA, colorB Color
+	colorA[0] = 1
+	colorB[0] = 2
+
+	essence := NewWithdrawalEssence(nil, nil, nil, []Withdrawal{
+		{Index: 0, Amount: 10, Color: colorA},
+		{Index: 1, Amount: 5, Color: colorA},
+		{Index: 2, Amount: 7, Color: colorB},
+	})
+
+	debit := essence.EscrowDebit()
+	assert.Equal(t, uint64(15), debit[colorA])
+	assert.Equal(t, uint64(7), debit[colorB])
+}